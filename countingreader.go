@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// progressInterval은 countingReader가 onProgress 콜백을 호출하는 최소
+// 간격이다. 매 Read마다 stderr에 쓰면 큰 파일에서 출력이 I/O 병목이 될 수
+// 있어 속도를 제한한다.
+const progressInterval = 200 * time.Millisecond
+
+// countingReader는 읽은 바이트 수를 추적하고, progressInterval 간격으로
+// rate-limited된 진행률 콜백을 호출하는 io.Reader 래퍼이다.
+type countingReader struct {
+	r          io.Reader
+	total      int64
+	read       int64
+	lastReport time.Time
+	onProgress func(read, total int64)
+}
+
+// newCountingReader는 r을 래핑해 onProgress로 실시간 진행률을 보고하는
+// countingReader를 만든다. total이 0이면 onProgress에는 0이 전달된다.
+func newCountingReader(r io.Reader, total int64, onProgress func(read, total int64)) *countingReader {
+	return &countingReader{r: r, total: total, onProgress: onProgress}
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += int64(n)
+
+	if c.onProgress != nil {
+		now := time.Now()
+		if now.Sub(c.lastReport) >= progressInterval || err != nil {
+			c.onProgress(c.read, c.total)
+			c.lastReport = now
+		}
+	}
+	return n, err
+}
+
+// printProgress는 name 전송의 현재 진행률을 한 줄로 표준 에러에 출력한다.
+func printProgress(name string, read, total int64) {
+	if total > 0 {
+		fmt.Fprintf(os.Stderr, "\r%s: %d/%d 바이트 (%.1f%%)", name, read, total, float64(read)/float64(total)*100)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r%s: %d 바이트", name, read)
+}
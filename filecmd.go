@@ -0,0 +1,465 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"github.com/quic-go/quic-go"
+
+	"projectno1/pkg/proto"
+)
+
+// defaultTLSConfig는 이 예제 클라이언트가 사용하는 QUIC용 TLS 설정이다.
+func defaultTLSConfig() *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		NextProtos:         []string{"quic-example"},
+	}
+}
+
+// newFileCmd는 Tailscale의 `tailscale file cp`/`file get` UX를 본뜬 파일
+// 전송 서브커맨드 트리를 만든다. 서버는 단일 공유 인박스를 가지므로,
+// cp/get은 각각 인박스로의 업로드와 인박스로부터의 수신에 대응한다.
+func newFileCmd() *ffcli.Command {
+	return &ffcli.Command{
+		Name:       "file",
+		ShortUsage: "projectno1 file <cp|get> ...",
+		ShortHelp:  "서버 인박스로 파일을 보내거나 받습니다",
+		Subcommands: []*ffcli.Command{
+			newCpCmd(),
+			newGetCmd(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+type cpArgs struct {
+	resumable bool
+	recursive bool
+	fromKey   string
+	toKey     string
+	threads   int
+}
+
+func newCpCmd() *ffcli.Command {
+	args := cpArgs{}
+	fs := flag.NewFlagSet("file cp", flag.ExitOnError)
+	fs.BoolVar(&args.resumable, "resumable", false, "청크 단위로 이어받기 업로드를 사용합니다")
+	fs.BoolVar(&args.recursive, "r", false, "localfiles를 디렉토리 하나로 보고 트리 전체를 매니페스트 기반으로 업로드합니다")
+	fs.StringVar(&args.fromKey, "from", "", "-to와 함께 쓰면 재업로드 없이 서버 인박스 안에서 이 키를 복사합니다")
+	fs.StringVar(&args.toKey, "to", "", "-from과 함께 쓰는 서버 측 복사의 대상 키")
+	fs.IntVar(&args.threads, "threads", defaultThreadCount, "-resumable 업로드에서 동시에 전송할 청크 워커 수")
+
+	return &ffcli.Command{
+		Name:       "cp",
+		ShortUsage: "projectno1 file cp <localfiles...> <peer>:\n  projectno1 file cp -r <localdir> <peer>:\n  projectno1 file cp -from <srckey> -to <dstkey> <peer>:",
+		ShortHelp:  "로컬 파일이나 디렉토리 트리를 서버 인박스로 업로드하거나, 인박스 안에서 키를 복사합니다",
+		FlagSet:    fs,
+		Exec: func(ctx context.Context, fsArgs []string) error {
+			return runCp(ctx, fsArgs, args)
+		},
+	}
+}
+
+// runCp는 `file cp` 서브커맨드를 실행한다. 마지막 인자는 Tailscale의
+// `<peer>:` 표기를 따라 콜론으로 끝나야 하며, 업로드 작업을 구분하는 용도로
+// 쓰인다 (이어받기 캐시의 jobID에 들어가는 "버킷" 값). 이 클라이언트는 아직
+// 단일 서버에만 연결하므로 실제 접속 주소는 항상 serverAddr이다.
+//
+// -from/-to가 둘 다 주어지면 로컬 파일을 올리는 대신 OpCopy로 서버 인박스
+// 안에서의 키 복사만 요청한다.
+func runCp(ctx context.Context, fsArgs []string, args cpArgs) error {
+	if len(fsArgs) < 1 {
+		return errors.New("사용법: projectno1 file cp <localfiles...> <peer>:")
+	}
+
+	target := fsArgs[len(fsArgs)-1]
+	if !strings.HasSuffix(target, ":") {
+		return fmt.Errorf("대상은 콜론으로 끝나야 합니다 (예: peer:): %q", target)
+	}
+	peer := strings.TrimSuffix(target, ":")
+	localFiles := fsArgs[:len(fsArgs)-1]
+
+	if args.fromKey != "" || args.toKey != "" {
+		if args.fromKey == "" || args.toKey == "" {
+			return errors.New("-from과 -to는 함께 지정해야 합니다")
+		}
+		if len(localFiles) != 0 {
+			return errors.New("-from/-to와 로컬 파일 인자는 함께 쓸 수 없습니다")
+		}
+
+		conn, err := connectToServer(defaultTLSConfig())
+		if err != nil {
+			return err
+		}
+		defer conn.CloseWithError(0, "클라이언트 종료")
+
+		if err := copyRemoteFile(conn, args.fromKey, args.toKey); err != nil {
+			return err
+		}
+		fmt.Printf("'%s' -> '%s' 서버 측 복사 완료\n", args.fromKey, args.toKey)
+		return nil
+	}
+
+	if len(localFiles) == 0 {
+		return errors.New("사용법: projectno1 file cp <localfiles...> <peer>:")
+	}
+
+	if args.recursive {
+		if len(localFiles) != 1 {
+			return errors.New("-r은 디렉토리 하나만 받습니다: projectno1 file cp -r <localdir> <peer>:")
+		}
+
+		conn, err := connectToServer(defaultTLSConfig())
+		if err != nil {
+			return err
+		}
+		defer conn.CloseWithError(0, "클라이언트 종료")
+
+		return treeUpload(conn, localFiles[0], peer)
+	}
+
+	conn, err := connectToServer(defaultTLSConfig())
+	if err != nil {
+		return err
+	}
+	defer conn.CloseWithError(0, "클라이언트 종료")
+
+	for _, path := range localFiles {
+		if args.resumable {
+			if err := uploadFileResumable(conn, path, peer, args.threads); err != nil {
+				return fmt.Errorf("'%s' 이어받기 업로드 실패: %w", path, err)
+			}
+			continue
+		}
+		if err := uploadSingleFile(conn, path); err != nil {
+			return fmt.Errorf("'%s' 업로드 실패: %w", path, err)
+		}
+	}
+	return nil
+}
+
+type getArgs struct {
+	wait      bool
+	verbose   bool
+	recursive bool
+	prefix    string
+	conflict  string
+}
+
+func newGetCmd() *ffcli.Command {
+	args := getArgs{}
+	fs := flag.NewFlagSet("file get", flag.ExitOnError)
+	fs.BoolVar(&args.wait, "wait", false, "인박스가 비어 있으면 파일이 도착할 때까지 기다립니다")
+	fs.BoolVar(&args.verbose, "verbose", false, "파일별 전송 속도를 표준 에러에 출력합니다")
+	fs.BoolVar(&args.recursive, "r", false, "-prefix 아래 트리 전체를 매니페스트 기반으로 내려받습니다")
+	fs.StringVar(&args.prefix, "prefix", "", "-r과 함께 쓰는, 내려받을 서버 쪽 트리의 경로")
+	fs.StringVar(&args.conflict, "conflict", "skip", "로컬에 같은 이름의 파일이 있을 때 동작: skip|overwrite|rename")
+
+	return &ffcli.Command{
+		Name:       "get",
+		ShortUsage: "projectno1 file get [--wait] [--verbose] [--conflict=skip|overwrite|rename] <dir>\n  projectno1 file get -r [--prefix=<path>] <dir>",
+		ShortHelp:  "서버 인박스의 파일이나 디렉토리 트리를 로컬로 내려받습니다",
+		FlagSet:    fs,
+		Exec: func(ctx context.Context, fsArgs []string) error {
+			return runGet(ctx, fsArgs, args)
+		},
+	}
+}
+
+// runGet은 `file get` 서브커맨드를 실행한다. 서버 인박스의 파일들을 dir로
+// 내려받고, 이름 충돌은 --conflict 모드로 처리한다. 내려받은 파일을 서버
+// 인박스에서 지우지는 않으므로, 다시 실행하면 같은 파일을 또 받는다. -r이
+// 주어지면 대신 -prefix 아래 트리 전체를 매니페스트 기반으로 내려받는다.
+func runGet(ctx context.Context, fsArgs []string, args getArgs) error {
+	if len(fsArgs) != 1 {
+		return errors.New("사용법: projectno1 file get [flags] <dir>")
+	}
+
+	destDir := fsArgs[0]
+	if err := ensureDir(destDir); err != nil {
+		return fmt.Errorf("대상 디렉토리 생성 실패: %w", err)
+	}
+
+	if args.recursive {
+		conn, err := connectToServer(defaultTLSConfig())
+		if err != nil {
+			return err
+		}
+		defer conn.CloseWithError(0, "클라이언트 종료")
+
+		return treeDownload(conn, args.prefix, destDir)
+	}
+
+	switch args.conflict {
+	case "skip", "overwrite", "rename":
+	default:
+		return fmt.Errorf("알 수 없는 conflict 모드: %s", args.conflict)
+	}
+
+	conn, err := connectToServer(defaultTLSConfig())
+	if err != nil {
+		return err
+	}
+	defer conn.CloseWithError(0, "클라이언트 종료")
+
+	names, err := waitForInbox(ctx, conn, args.wait)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Println("인박스에 받을 파일이 없습니다.")
+		return nil
+	}
+
+	for _, name := range names {
+		if err := validateFilename(name); err != nil {
+			fmt.Fprintf(os.Stderr, "'%s' 건너뜀: %v\n", name, err)
+			continue
+		}
+
+		destPath, err := resolveConflict(filepath.Join(destDir, name), args.conflict)
+		if err != nil {
+			return fmt.Errorf("'%s' 충돌 처리 오류: %w", name, err)
+		}
+		if destPath == "" {
+			fmt.Printf("'%s' 이미 존재하여 건너뜁니다.\n", name)
+			continue
+		}
+
+		if err := downloadSingleFile(conn, name, destPath, args.verbose); err != nil {
+			return fmt.Errorf("'%s' 다운로드 실패: %w", name, err)
+		}
+		if err := quarantineFile(destPath); err != nil {
+			fmt.Fprintf(os.Stderr, "'%s' 격리 속성 설정 실패: %v\n", name, err)
+		}
+	}
+	return nil
+}
+
+// waitForInbox는 서버 인박스의 파일명 목록을 가져온다. wait가 true면 목록이
+// 비어 있는 동안 일정 간격으로 재시도하며, ctx가 끝나면 즉시 반환한다.
+func waitForInbox(ctx context.Context, conn quic.Connection, wait bool) ([]string, error) {
+	for {
+		names, err := listRemoteFiles(conn)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) > 0 || !wait {
+			return names, nil
+		}
+
+		select {
+		case <-time.After(2 * time.Second):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// validateFilename은 서버가 돌려준 파일명을 로컬에 쓰기 전에 검증한다.
+// 경로 구분자나 올바르지 않은 UTF-8이 있으면 경로 이탈로 이어질 수 있으므로
+// 거부한다.
+func validateFilename(name string) error {
+	if name == "" {
+		return errors.New("빈 파일명입니다")
+	}
+	if !utf8.ValidString(name) {
+		return errors.New("올바르지 않은 UTF-8 파일명입니다")
+	}
+	if strings.ContainsAny(name, "/\\") {
+		return errors.New("파일명에 경로 구분자를 포함할 수 없습니다")
+	}
+	if name == "." || name == ".." {
+		return errors.New("올바르지 않은 파일명입니다")
+	}
+	return nil
+}
+
+// resolveConflict는 conflict 모드에 따라 실제로 쓸 경로를 정한다. destPath가
+// 아직 없으면 그대로 쓴다. skip이면 빈 문자열을 돌려줘 다운로드를 건너뛰게
+// 하고, overwrite면 destPath를 그대로, rename이면 "이름 (n).ext" 형태의
+// 비어 있는 이름을 찾아 돌려준다.
+func resolveConflict(destPath, mode string) (string, error) {
+	_, err := os.Stat(destPath)
+	if os.IsNotExist(err) {
+		return destPath, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	switch mode {
+	case "skip":
+		return "", nil
+	case "overwrite":
+		return destPath, nil
+	case "rename":
+		ext := filepath.Ext(destPath)
+		base := strings.TrimSuffix(destPath, ext)
+		for i := 1; ; i++ {
+			candidate := fmt.Sprintf("%s (%d)%s", base, i, ext)
+			if _, err := os.Stat(candidate); os.IsNotExist(err) {
+				return candidate, nil
+			}
+		}
+	default:
+		return "", fmt.Errorf("알 수 없는 conflict 모드: %s", mode)
+	}
+}
+
+// uploadSingleFile은 경로 하나를 단일 스트림으로 서버에 업로드한다.
+// `file cp`가 -resumable 없이 호출하는 기본 경로이다.
+func uploadSingleFile(conn quic.Connection, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("파일 열기 오류: %w", err)
+	}
+	defer file.Close()
+
+	fileName := filepath.Base(path)
+	meta, err := fileMetadata(file, fileName)
+	if err != nil {
+		return fmt.Errorf("메타데이터 계산 오류: %w", err)
+	}
+
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+	defer stream.Close()
+
+	req := proto.RequestHeader{Opcode: proto.OpUpload, Meta: meta}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		return fmt.Errorf("요청 전송 오류: %w", err)
+	}
+
+	// sha256 계산을 위해 읽었던 위치를 되감고 파일 내용 전송
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("파일 되감기 오류: %w", err)
+	}
+	n, err := io.Copy(stream, file)
+	if err != nil {
+		return fmt.Errorf("파일 전송 오류: %w", err)
+	}
+
+	if err := stream.Close(); err != nil {
+		return fmt.Errorf("스트림 닫기 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return fmt.Errorf("응답 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return fmt.Errorf("'%s' 파일 업로드 실패: %s", fileName, resp.Message)
+	}
+
+	fmt.Printf("'%s' 파일 업로드 완료: %d 바이트 전송\n", fileName, n)
+	return nil
+}
+
+// downloadSingleFile은 서버 인박스의 name을 단일 스트림으로 받아 destPath에
+// 쓴다. verbose가 true면 countingReader로 진행률을 표준 에러에 출력한다.
+func downloadSingleFile(conn quic.Connection, name, destPath string, verbose bool) error {
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+	defer stream.Close()
+
+	req := proto.RequestHeader{Opcode: proto.OpDownload, Meta: proto.Metadata{Filename: name}}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		return fmt.Errorf("요청 전송 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return fmt.Errorf("응답 헤더 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return fmt.Errorf("'%s' 파일을 찾을 수 없습니다: %s", name, resp.Message)
+	}
+
+	file, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("파일 생성 오류: %w", err)
+	}
+	defer file.Close()
+
+	// 응답 헤더에 실린 PayloadLen만큼만 읽어 파일 경계를 지킨다
+	var reader io.Reader = io.LimitReader(stream, resp.PayloadLen)
+	if verbose {
+		reader = newCountingReader(reader, resp.PayloadLen, func(read, total int64) {
+			printProgress(name, read, total)
+		})
+	}
+
+	received, err := io.Copy(file, reader)
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("파일 수신 오류: %w", err)
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr)
+	}
+
+	if received == resp.PayloadLen {
+		fmt.Printf("'%s' 파일 다운로드 성공: %d 바이트\n", name, received)
+	} else {
+		fmt.Printf("'%s' 파일 다운로드 완료: %d/%d 바이트\n", name, received, resp.PayloadLen)
+	}
+	return nil
+}
+
+// listRemoteFiles는 LIST 요청으로 서버 인박스의 파일명 목록을 가져온다.
+func listRemoteFiles(conn quic.Connection) ([]string, error) {
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+	defer stream.Close()
+
+	req := proto.RequestHeader{Opcode: proto.OpList}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		return nil, fmt.Errorf("요청 전송 오류: %w", err)
+	}
+	if err := stream.Close(); err != nil {
+		return nil, fmt.Errorf("스트림 닫기 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return nil, fmt.Errorf("응답 헤더 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return nil, fmt.Errorf("목록 조회 실패: %s", resp.Message)
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(stream, resp.PayloadLen))
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("목록 읽기 오류: %w", err)
+	}
+
+	listed := strings.TrimSpace(string(payload))
+	if listed == "" {
+		return nil, nil
+	}
+
+	var names []string
+	for _, line := range strings.Split(listed, "\n") {
+		names = append(names, strings.Split(line, " (")[0])
+	}
+	return names, nil
+}
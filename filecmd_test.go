@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateFilename(t *testing.T) {
+	valid := []string{"a.txt", "한글.txt", "no-extension"}
+	for _, name := range valid {
+		if err := validateFilename(name); err != nil {
+			t.Errorf("validateFilename(%q) = %v, want nil", name, err)
+		}
+	}
+
+	invalid := []string{"", ".", "..", "a/b", "a\\b", "\xff\xfe"}
+	for _, name := range invalid {
+		if err := validateFilename(name); err == nil {
+			t.Errorf("validateFilename(%q) = nil, want error", name)
+		}
+	}
+}
+
+func TestResolveConflictNoExisting(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "new.txt")
+	got, err := resolveConflict(dest, "skip")
+	if err != nil || got != dest {
+		t.Errorf("resolveConflict(new, skip) = (%q, %v), want (%q, nil)", got, err, dest)
+	}
+}
+
+func TestResolveConflictSkip(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "exists.txt")
+	if err := os.WriteFile(dest, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	got, err := resolveConflict(dest, "skip")
+	if err != nil || got != "" {
+		t.Errorf("resolveConflict(existing, skip) = (%q, %v), want (\"\", nil)", got, err)
+	}
+}
+
+func TestResolveConflictOverwrite(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "exists.txt")
+	if err := os.WriteFile(dest, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	got, err := resolveConflict(dest, "overwrite")
+	if err != nil || got != dest {
+		t.Errorf("resolveConflict(existing, overwrite) = (%q, %v), want (%q, nil)", got, err, dest)
+	}
+}
+
+func TestResolveConflictRename(t *testing.T) {
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "exists.txt")
+	if err := os.WriteFile(dest, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	got, err := resolveConflict(dest, "rename")
+	if err != nil {
+		t.Fatalf("resolveConflict(existing, rename): %v", err)
+	}
+	want := filepath.Join(dir, "exists (1).txt")
+	if got != want {
+		t.Errorf("resolveConflict(existing, rename) = %q, want %q", got, want)
+	}
+
+	if err := os.WriteFile(want, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	got2, err := resolveConflict(dest, "rename")
+	if err != nil {
+		t.Fatalf("resolveConflict(existing again, rename): %v", err)
+	}
+	want2 := filepath.Join(dir, "exists (2).txt")
+	if got2 != want2 {
+		t.Errorf("resolveConflict(existing again, rename) = %q, want %q", got2, want2)
+	}
+}
+
+func TestResolveConflictUnknownMode(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "exists.txt")
+	if err := os.WriteFile(dest, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := resolveConflict(dest, "bogus"); err == nil {
+		t.Error("resolveConflict(existing, bogus) = nil error, want error")
+	}
+}
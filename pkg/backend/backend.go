@@ -0,0 +1,88 @@
+// Package backend은 서버가 객체 저장소 구현을 갈아끼울 수 있도록 공통
+// 인터페이스를 정의한다. 현재는 로컬 디스크 구현(local.go)만 있으며, 이
+// 인터페이스로 호출하는 서버가 이 트리에 아직 없어 패키지 전체가 사실상
+// 미사용 상태이다. 서버가 추가되어 --backend 플래그 등으로 실제로
+// 호출하게 되면 그때 맞춰 확장한다.
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Metadata는 객체 하나에 함께 저장되는 메타데이터이다. ContentType은 MIME
+// 타입, AccessTier는 "hot"/"cool"/"archive"처럼 백엔드가 지원하는 접근
+// 등급을 가리키며, 등급을 지원하지 않는 백엔드(로컬 디스크 등)에서는
+// 무시된다.
+type Metadata struct {
+	ContentType string
+	AccessTier  string
+}
+
+// ObjectInfo는 List/Stat이 돌려주는 객체 하나의 요약 정보이다.
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	ModTime int64
+	Metadata
+}
+
+// Backend는 키-값 형태의 객체 저장소를 추상화한다. 구현체는 local.go에
+// 있다.
+type Backend interface {
+	// Put은 r의 내용을 key로 저장하고 meta를 함께 기록한다. 이미 같은 키가
+	// 있으면 덮어쓴다.
+	Put(ctx context.Context, key string, r io.Reader, meta Metadata) error
+
+	// Get은 key의 내용을 스트리밍으로 읽을 수 있는 ReadCloser와 메타데이터를
+	// 돌려준다. 존재하지 않으면 ErrNotExist를 반환한다.
+	Get(ctx context.Context, key string) (io.ReadCloser, Metadata, error)
+
+	// List는 prefix로 시작하는 모든 키의 정보를 돌려준다.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+
+	// Stat은 내용을 읽지 않고 key의 메타데이터만 조회한다.
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+
+	// Delete는 key를 저장소에서 제거한다. 존재하지 않아도 오류가 아니다.
+	Delete(ctx context.Context, key string) error
+
+	// Copy는 srcKey의 내용을 다시 업로드하지 않고 dstKey로 복사한다.
+	Copy(ctx context.Context, srcKey, dstKey string) error
+
+	// ChangeMime은 key의 content-type 메타데이터만 갱신한다.
+	ChangeMime(ctx context.Context, key, contentType string) error
+}
+
+// ErrNotExist는 존재하지 않는 키에 대해 Get/Stat/ChangeMime을 호출했을 때
+// 반환된다.
+var ErrNotExist = fmt.Errorf("backend: 객체가 존재하지 않습니다")
+
+// Options는 백엔드별로 검증되는 업로드/목록 조회 옵션이다.
+type Options struct {
+	// ChunkSize는 업로드 스트리밍에 쓰이는 버퍼 크기이다. 0이면 백엔드의
+	// 기본값을 쓴다.
+	ChunkSize int
+
+	// ListPageSize는 List가 백엔드에 한 번에 요청하는 페이지 크기이다.
+	// 0이면 백엔드의 기본값을 쓴다.
+	ListPageSize int
+}
+
+// New는 "file:///..." 형태의 rawURL을 파싱해 알맞은 Backend 구현을 만든다.
+// --backend 서버 플래그 값을 그대로 받는 용도이다.
+func New(rawURL string, opts Options) (Backend, error) {
+	scheme, rest, ok := strings.Cut(rawURL, "://")
+	if !ok {
+		return nil, fmt.Errorf("backend: 스킴이 없는 URL입니다 (file:// 필요): %q", rawURL)
+	}
+
+	switch scheme {
+	case "file":
+		return newLocalBackend(rest, opts)
+	default:
+		return nil, fmt.Errorf("backend: 지원하지 않는 스킴입니다: %q", scheme)
+	}
+}
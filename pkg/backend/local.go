@@ -0,0 +1,259 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// defaultChunkSize는 ChunkSize 옵션이 지정되지 않았을 때 Put이 사용하는
+// 복사 버퍼 크기이다.
+const defaultChunkSize = 32 * 1024
+
+// localBackend는 로컬 디스크를 저장소로 쓰는 Backend 구현이다. 각 객체는
+// root 아래에 키 그대로의 경로로 저장되고, 메타데이터는 "<key>.meta.json"
+// 사이드카 파일에 JSON으로 함께 저장된다.
+type localBackend struct {
+	root string
+	opts Options
+}
+
+func newLocalBackend(root string, opts Options) (Backend, error) {
+	if root == "" {
+		return nil, fmt.Errorf("backend: file:// URL에 경로가 없습니다")
+	}
+	if opts.ChunkSize < 0 || opts.ListPageSize < 0 {
+		return nil, fmt.Errorf("backend: ChunkSize/ListPageSize는 음수일 수 없습니다")
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("backend: 루트 디렉토리 생성 실패: %w", err)
+	}
+	return &localBackend{root: root, opts: opts}, nil
+}
+
+// resolve는 키를 root 아래의 실제 파일 경로로 바꾼다. "/"+key를 Clean해
+// ".."로 root를 벗어나는 키를 root 안으로 접어 넣는다.
+func (b *localBackend) resolve(key string) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("backend: 빈 키입니다")
+	}
+	clean := filepath.Clean("/" + key)
+	if clean == "/" {
+		return "", fmt.Errorf("backend: 올바르지 않은 키입니다: %q", key)
+	}
+	return filepath.Join(b.root, clean), nil
+}
+
+func (b *localBackend) metaPath(path string) string {
+	return path + ".meta.json"
+}
+
+func (b *localBackend) Put(ctx context.Context, key string, r io.Reader, meta Metadata) error {
+	path, err := b.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("backend: 디렉토리 생성 실패: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("backend: 파일 생성 실패: %w", err)
+	}
+	defer f.Close()
+
+	chunkSize := b.opts.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	}
+	if _, err := io.CopyBuffer(f, r, make([]byte, chunkSize)); err != nil {
+		return fmt.Errorf("backend: 쓰기 실패: %w", err)
+	}
+
+	return b.writeMeta(path, meta)
+}
+
+func (b *localBackend) Get(ctx context.Context, key string) (io.ReadCloser, Metadata, error) {
+	path, err := b.resolve(key)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, Metadata{}, ErrNotExist
+	}
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("backend: 파일 열기 실패: %w", err)
+	}
+
+	meta, err := b.readMeta(path)
+	if err != nil {
+		f.Close()
+		return nil, Metadata{}, err
+	}
+	return f, meta, nil
+}
+
+func (b *localBackend) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	path, err := b.resolve(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return ObjectInfo{}, ErrNotExist
+	}
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("backend: 파일 정보 조회 실패: %w", err)
+	}
+
+	meta, err := b.readMeta(path)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime().Unix(), Metadata: meta}, nil
+}
+
+func (b *localBackend) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var out []ObjectInfo
+	err := filepath.WalkDir(b.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(path, ".meta.json") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		meta, err := b.readMeta(path)
+		if err != nil {
+			return err
+		}
+		out = append(out, ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime().Unix(), Metadata: meta})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("backend: 목록 조회 실패: %w", err)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+func (b *localBackend) Delete(ctx context.Context, key string) error {
+	path, err := b.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("backend: 삭제 실패: %w", err)
+	}
+	os.Remove(b.metaPath(path))
+	return nil
+}
+
+func (b *localBackend) Copy(ctx context.Context, srcKey, dstKey string) error {
+	srcPath, err := b.resolve(srcKey)
+	if err != nil {
+		return err
+	}
+	dstPath, err := b.resolve(dstKey)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(srcPath)
+	if os.IsNotExist(err) {
+		return ErrNotExist
+	}
+	if err != nil {
+		return fmt.Errorf("backend: 원본 열기 실패: %w", err)
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+		return fmt.Errorf("backend: 디렉토리 생성 실패: %w", err)
+	}
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return fmt.Errorf("backend: 대상 생성 실패: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("backend: 복사 실패: %w", err)
+	}
+
+	meta, err := b.readMeta(srcPath)
+	if err != nil {
+		return err
+	}
+	return b.writeMeta(dstPath, meta)
+}
+
+func (b *localBackend) ChangeMime(ctx context.Context, key, contentType string) error {
+	path, err := b.resolve(key)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return ErrNotExist
+	} else if err != nil {
+		return fmt.Errorf("backend: 파일 정보 조회 실패: %w", err)
+	}
+
+	meta, err := b.readMeta(path)
+	if err != nil {
+		return err
+	}
+	meta.ContentType = contentType
+	return b.writeMeta(path, meta)
+}
+
+func (b *localBackend) readMeta(path string) (Metadata, error) {
+	data, err := os.ReadFile(b.metaPath(path))
+	if os.IsNotExist(err) {
+		return Metadata{}, nil
+	}
+	if err != nil {
+		return Metadata{}, fmt.Errorf("backend: 메타데이터 읽기 실패: %w", err)
+	}
+
+	var meta Metadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return Metadata{}, fmt.Errorf("backend: 메타데이터 파싱 실패: %w", err)
+	}
+	return meta, nil
+}
+
+func (b *localBackend) writeMeta(path string, meta Metadata) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("backend: 메타데이터 인코딩 실패: %w", err)
+	}
+	if err := os.WriteFile(b.metaPath(path), data, 0644); err != nil {
+		return fmt.Errorf("backend: 메타데이터 쓰기 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func newTestLocalBackend(t *testing.T) Backend {
+	t.Helper()
+	b, err := New("file://"+t.TempDir(), Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return b
+}
+
+func TestLocalBackendPutGet(t *testing.T) {
+	b := newTestLocalBackend(t)
+	ctx := context.Background()
+
+	if err := b.Put(ctx, "a/b.txt", bytes.NewReader([]byte("hello")), Metadata{ContentType: "text/plain"}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	r, meta, err := b.Get(ctx, "a/b.txt")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Errorf("content = %q, want %q", data, "hello")
+	}
+	if meta.ContentType != "text/plain" {
+		t.Errorf("ContentType = %q, want %q", meta.ContentType, "text/plain")
+	}
+}
+
+func TestLocalBackendGetNotExist(t *testing.T) {
+	b := newTestLocalBackend(t)
+	if _, _, err := b.Get(context.Background(), "missing.txt"); !errors.Is(err, ErrNotExist) {
+		t.Errorf("Get(missing) error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestLocalBackendStat(t *testing.T) {
+	b := newTestLocalBackend(t)
+	ctx := context.Background()
+
+	if err := b.Put(ctx, "x.bin", bytes.NewReader([]byte("abcd")), Metadata{}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	info, err := b.Stat(ctx, "x.bin")
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Key != "x.bin" || info.Size != 4 {
+		t.Errorf("Stat = %+v, want Key=x.bin Size=4", info)
+	}
+
+	if _, err := b.Stat(ctx, "missing.bin"); !errors.Is(err, ErrNotExist) {
+		t.Errorf("Stat(missing) error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestLocalBackendList(t *testing.T) {
+	b := newTestLocalBackend(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"dir/a.txt", "dir/b.txt", "other.txt"} {
+		if err := b.Put(ctx, key, bytes.NewReader([]byte(key)), Metadata{}); err != nil {
+			t.Fatalf("Put(%s): %v", key, err)
+		}
+	}
+
+	got, err := b.List(ctx, "dir/")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(got) != 2 || got[0].Key != "dir/a.txt" || got[1].Key != "dir/b.txt" {
+		t.Errorf("List(dir/) = %+v, want [dir/a.txt dir/b.txt]", got)
+	}
+}
+
+func TestLocalBackendDelete(t *testing.T) {
+	b := newTestLocalBackend(t)
+	ctx := context.Background()
+
+	if err := b.Put(ctx, "gone.txt", bytes.NewReader([]byte("x")), Metadata{}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := b.Delete(ctx, "gone.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, _, err := b.Get(ctx, "gone.txt"); !errors.Is(err, ErrNotExist) {
+		t.Errorf("Get after Delete error = %v, want ErrNotExist", err)
+	}
+
+	// 존재하지 않는 키를 지워도 오류가 아니다.
+	if err := b.Delete(ctx, "never-existed.txt"); err != nil {
+		t.Errorf("Delete(missing) = %v, want nil", err)
+	}
+}
+
+func TestLocalBackendCopy(t *testing.T) {
+	b := newTestLocalBackend(t)
+	ctx := context.Background()
+
+	if err := b.Put(ctx, "src.txt", bytes.NewReader([]byte("copied")), Metadata{ContentType: "text/plain"}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := b.Copy(ctx, "src.txt", "dst.txt"); err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+
+	r, meta, err := b.Get(ctx, "dst.txt")
+	if err != nil {
+		t.Fatalf("Get(dst): %v", err)
+	}
+	defer r.Close()
+	data, _ := io.ReadAll(r)
+	if string(data) != "copied" || meta.ContentType != "text/plain" {
+		t.Errorf("Get(dst) = %q, %+v, want %q, text/plain", data, meta, "copied")
+	}
+}
+
+func TestLocalBackendChangeMime(t *testing.T) {
+	b := newTestLocalBackend(t)
+	ctx := context.Background()
+
+	if err := b.Put(ctx, "m.txt", bytes.NewReader([]byte("x")), Metadata{ContentType: "text/plain"}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := b.ChangeMime(ctx, "m.txt", "application/json"); err != nil {
+		t.Fatalf("ChangeMime: %v", err)
+	}
+
+	info, err := b.Stat(ctx, "m.txt")
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.ContentType != "application/json" {
+		t.Errorf("ContentType = %q, want application/json", info.ContentType)
+	}
+
+	if err := b.ChangeMime(ctx, "missing.txt", "text/plain"); !errors.Is(err, ErrNotExist) {
+		t.Errorf("ChangeMime(missing) error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestLocalBackendResolveClampsTraversal(t *testing.T) {
+	root := t.TempDir()
+	b := &localBackend{root: root}
+
+	for _, key := range []string{"../../etc/passwd", "../secret.txt", "a/../../b"} {
+		path, err := b.resolve(key)
+		if err != nil {
+			t.Fatalf("resolve(%q): %v", key, err)
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == ".." || filepath.IsAbs(rel) || len(rel) >= 2 && rel[:2] == ".." {
+			t.Errorf("resolve(%q) = %q, escapes root %q (rel=%q)", key, path, root, rel)
+		}
+	}
+
+	if _, err := b.resolve(""); err == nil {
+		t.Error("resolve(\"\") = nil error, want error")
+	}
+}
@@ -0,0 +1,93 @@
+// Package manifest은 디렉토리 트리 하나를 구성하는 파일들의 목록(매니페스트)을
+// 만들고 와이어로 주고받는 기능을 제공한다. TREE_UP 요청은 먼저 제어
+// 스트림으로 매니페스트를 보내 presence 비트맵으로 어느 파일이 이미 있는지
+// 확인한 뒤, 빠진 파일만 각자의 스트림으로 전송한다. TREE_DOWN 요청은
+// 제어 스트림으로 매니페스트를 받은 뒤, 그 안의 모든 파일을 각자의
+// 스트림으로 내려받는다.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Entry는 트리 안의 파일 하나를 나타낸다. RelPath는 트리 루트를 기준으로 한
+// 슬래시(/) 구분 상대 경로이다.
+type Entry struct {
+	RelPath string
+	Size    int64
+	ModTime int64
+	SHA256  string
+}
+
+// Manifest는 트리 하나에 속한 Entry들의 목록이다.
+type Manifest struct {
+	Entries []Entry
+}
+
+// Build는 root 아래의 모든 일반 파일을 filepath.Walk로 순회하며 매니페스트를
+// 만든다. 각 파일의 sha256을 계산하므로 큰 트리에서는 시간이 걸릴 수 있다.
+func Build(root string) (*Manifest, error) {
+	var m Manifest
+	err := filepath.Walk(root, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("상대 경로 계산 실패: %w", err)
+		}
+
+		sum, err := sha256File(path)
+		if err != nil {
+			return fmt.Errorf("'%s' sha256 계산 실패: %w", rel, err)
+		}
+
+		m.Entries = append(m.Entries, Entry{
+			RelPath: filepath.ToSlash(rel),
+			Size:    info.Size(),
+			ModTime: info.ModTime().Unix(),
+			SHA256:  sum,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("manifest: 디렉토리 순회 실패: %w", err)
+	}
+	return &m, nil
+}
+
+// Missing은 p에서 빠진 것으로 표시된(또는 p가 더 짧아 범위를 벗어난)
+// Entry들을 Manifest에 쓰인 순서 그대로 돌려준다.
+func (m *Manifest) Missing(p Presence) []Entry {
+	var out []Entry
+	for i, e := range m.Entries {
+		if i >= len(p) || !p[i] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
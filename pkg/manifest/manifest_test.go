@@ -0,0 +1,121 @@
+package manifest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"projectno1/pkg/wire"
+)
+
+func TestBuild(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "b.txt"), []byte("world"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	m, err := Build(root)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(m.Entries) != 2 {
+		t.Fatalf("len(Entries) = %d, want 2", len(m.Entries))
+	}
+
+	byPath := make(map[string]Entry, len(m.Entries))
+	for _, e := range m.Entries {
+		byPath[e.RelPath] = e
+	}
+	a, ok := byPath["a.txt"]
+	if !ok || a.Size != 5 {
+		t.Errorf("a.txt entry = %+v, ok=%v, want Size=5", a, ok)
+	}
+	if _, ok := byPath["sub/b.txt"]; !ok {
+		t.Errorf("sub/b.txt entry missing from %+v", byPath)
+	}
+}
+
+func TestMissing(t *testing.T) {
+	m := &Manifest{Entries: []Entry{
+		{RelPath: "a"}, {RelPath: "b"}, {RelPath: "c"},
+	}}
+
+	got := m.Missing(Presence{true, false})
+	if len(got) != 2 || got[0].RelPath != "b" || got[1].RelPath != "c" {
+		t.Errorf("Missing = %+v, want [b c]", got)
+	}
+}
+
+func TestManifestEncodeDecodeRoundTrip(t *testing.T) {
+	m := &Manifest{Entries: []Entry{
+		{RelPath: "a.txt", Size: 5, ModTime: 111, SHA256: "aaa"},
+		{RelPath: "sub/b.txt", Size: 6, ModTime: 222, SHA256: "bbb"},
+	}}
+
+	var buf bytes.Buffer
+	if err := m.Encode(&buf); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(got.Entries) != len(m.Entries) {
+		t.Fatalf("len(Entries) = %d, want %d", len(got.Entries), len(m.Entries))
+	}
+	for i := range m.Entries {
+		if got.Entries[i] != m.Entries[i] {
+			t.Errorf("Entries[%d] = %+v, want %+v", i, got.Entries[i], m.Entries[i])
+		}
+	}
+}
+
+func TestPresenceEncodeDecodeRoundTrip(t *testing.T) {
+	p := Presence{true, false, true, true, false}
+
+	var buf bytes.Buffer
+	if err := p.Encode(&buf); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got, err := DecodePresence(&buf)
+	if err != nil {
+		t.Fatalf("DecodePresence: %v", err)
+	}
+	if len(got) != len(p) {
+		t.Fatalf("len = %d, want %d", len(got), len(p))
+	}
+	for i := range p {
+		if got[i] != p[i] {
+			t.Errorf("bit %d = %v, want %v", i, got[i], p[i])
+		}
+	}
+}
+
+func TestDecodeRejectsOversizedCount(t *testing.T) {
+	var buf bytes.Buffer
+	if err := wire.WriteUvarint(&buf, wire.MaxLen+1); err != nil {
+		t.Fatalf("WriteUvarint: %v", err)
+	}
+	if _, err := Decode(&buf); err == nil {
+		t.Error("Decode with count > MaxLen = nil error, want error")
+	}
+}
+
+func TestDecodePresenceRejectsOversizedCount(t *testing.T) {
+	var buf bytes.Buffer
+	if err := wire.WriteUvarint(&buf, wire.MaxLen+1); err != nil {
+		t.Fatalf("WriteUvarint: %v", err)
+	}
+	if _, err := DecodePresence(&buf); err == nil {
+		t.Error("DecodePresence with count > MaxLen = nil error, want error")
+	}
+}
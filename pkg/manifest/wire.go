@@ -0,0 +1,106 @@
+package manifest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"projectno1/pkg/wire"
+)
+
+// Presence는 어느 Manifest의 Entries와 같은 길이(또는 그보다 짧은) 비트맵으로,
+// 인덱스 i번째 비트가 서 있으면 수신 측이 해당 Entry를 이미 갖고 있다는
+// 뜻이다.
+type Presence []bool
+
+// Encode는 매니페스트를 항목 수(uvarint)와 각 항목의
+// relpath/size/modtime/sha256 순서로 w에 써서 제어 스트림 위에서 보낼 수
+// 있는 프레임을 만든다.
+func (m *Manifest) Encode(w io.Writer) error {
+	if err := wire.WriteUvarint(w, uint64(len(m.Entries))); err != nil {
+		return err
+	}
+	for _, e := range m.Entries {
+		if err := wire.WriteString(w, e.RelPath); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, e.Size); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, e.ModTime); err != nil {
+			return err
+		}
+		if err := wire.WriteString(w, e.SHA256); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decode는 Encode의 반대 동작을 수행하여 매니페스트를 읽는다.
+func Decode(r io.Reader) (*Manifest, error) {
+	count, err := wire.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: 항목 수 읽기 오류: %w", err)
+	}
+	if count > wire.MaxLen {
+		return nil, fmt.Errorf("manifest: 항목 수가 한도를 넘었습니다: %d > %d", count, wire.MaxLen)
+	}
+
+	m := &Manifest{Entries: make([]Entry, 0, count)}
+	for i := uint64(0); i < count; i++ {
+		var e Entry
+		if e.RelPath, err = wire.ReadString(r); err != nil {
+			return nil, fmt.Errorf("manifest: 경로 읽기 오류: %w", err)
+		}
+		if err := binary.Read(r, binary.BigEndian, &e.Size); err != nil {
+			return nil, fmt.Errorf("manifest: 크기 읽기 오류: %w", err)
+		}
+		if err := binary.Read(r, binary.BigEndian, &e.ModTime); err != nil {
+			return nil, fmt.Errorf("manifest: 수정시각 읽기 오류: %w", err)
+		}
+		if e.SHA256, err = wire.ReadString(r); err != nil {
+			return nil, fmt.Errorf("manifest: sha256 읽기 오류: %w", err)
+		}
+		m.Entries = append(m.Entries, e)
+	}
+	return m, nil
+}
+
+// Encode는 p를 항목 수(uvarint)와 그 수만큼의 비트를 담은 바이트들로
+// 인코딩하여 w에 쓴다.
+func (p Presence) Encode(w io.Writer) error {
+	if err := wire.WriteUvarint(w, uint64(len(p))); err != nil {
+		return err
+	}
+	packed := make([]byte, (len(p)+7)/8)
+	for i, has := range p {
+		if has {
+			packed[i/8] |= 1 << uint(i%8)
+		}
+	}
+	_, err := w.Write(packed)
+	return err
+}
+
+// DecodePresence는 Presence.Encode의 반대 동작을 수행한다.
+func DecodePresence(r io.Reader) (Presence, error) {
+	count, err := wire.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: presence 길이 읽기 오류: %w", err)
+	}
+	if count > wire.MaxLen {
+		return nil, fmt.Errorf("manifest: presence 길이가 한도를 넘었습니다: %d > %d", count, wire.MaxLen)
+	}
+
+	packed := make([]byte, (count+7)/8)
+	if _, err := io.ReadFull(r, packed); err != nil {
+		return nil, fmt.Errorf("manifest: presence 비트맵 읽기 오류: %w", err)
+	}
+
+	p := make(Presence, count)
+	for i := range p {
+		p[i] = packed[i/8]&(1<<uint(i%8)) != 0
+	}
+	return p, nil
+}
@@ -0,0 +1,242 @@
+// Package proto는 클라이언트와 서버가 QUIC 스트림 위에서 주고받는
+// 요청/응답 프레임의 와이어 포맷을 정의한다. 길이 프리픽스 기반의
+// 바이너리 인코딩을 사용하므로, 새 커맨드는 헤더 레이아웃(magic/version/
+// opcode/메타데이터 길이)을 건드리지 않고 Opcode를 늘려 추가할 수 있다.
+// 새 Metadata 필드는 WriteRequest와 ReadRequest의 메타데이터 블록 인코딩에도
+// 함께 추가해야 와이어에 실린다.
+package proto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"projectno1/pkg/wire"
+)
+
+// Magic은 프레임의 시작을 식별하는 매직 넘버이다 ("PNO1").
+const Magic uint32 = 0x504e4f31
+
+// Version은 현재 프로토콜 버전이다.
+const Version uint8 = 1
+
+// Opcode는 요청의 종류를 나타낸다.
+type Opcode uint8
+
+const (
+	OpUpload Opcode = iota + 1
+	OpDownload
+	OpList
+	OpDelete
+	OpStat
+	OpResume
+	OpCommit
+	OpCopy
+	OpTreeUp
+	OpTreeDown
+)
+
+// String은 로그 출력을 위한 opcode의 사람이 읽을 수 있는 이름을 반환한다.
+func (op Opcode) String() string {
+	switch op {
+	case OpUpload:
+		return "UPLOAD"
+	case OpDownload:
+		return "DOWNLOAD"
+	case OpList:
+		return "LIST"
+	case OpDelete:
+		return "DELETE"
+	case OpStat:
+		return "STAT"
+	case OpResume:
+		return "RESUME"
+	case OpCommit:
+		return "COMMIT"
+	case OpCopy:
+		return "CP"
+	case OpTreeUp:
+		return "TREE_UP"
+	case OpTreeDown:
+		return "TREE_DOWN"
+	default:
+		return fmt.Sprintf("OPCODE(%d)", uint8(op))
+	}
+}
+
+// Status는 응답 상태 코드이다.
+type Status uint8
+
+const (
+	StatusOK Status = iota
+	StatusError
+	StatusNotFound
+)
+
+// Metadata는 요청 헤더에 함께 실리는 파일 메타데이터이다. 응답 헤더에는
+// 실리지 않는다.
+type Metadata struct {
+	Filename   string
+	Size       int64
+	ModTime    int64  // unix seconds
+	SHA256     string // hex-encoded. 단일 파일/청크 업로드 요청과 COMMIT에서 채워진다.
+	MimeType   string
+	JobID      string // RESUME/COMMIT 및 청크 업로드에서 이어받기 작업을 식별한다.
+	ChunkIndex int32  // JobID가 채워진 OpUpload 요청에서, 이 요청이 속한 청크의 인덱스.
+	DestKey    string // OpCopy 요청에서 Filename(원본 키)을 복사할 대상 키.
+}
+
+// RequestHeader는 클라이언트가 보내는 요청 프레임의 헤더이다.
+// 헤더 뒤에는 Opcode에 따라 실제 페이로드(파일 바이트열 등)가 이어진다.
+type RequestHeader struct {
+	Opcode Opcode
+	Meta   Metadata
+}
+
+// ResponseHeader는 서버가 보내는 응답 프레임의 헤더이다.
+// 헤더 뒤에 PayloadLen 바이트만큼의 페이로드가 이어진다.
+type ResponseHeader struct {
+	Status     Status
+	Message    string
+	PayloadLen int64
+}
+
+// WriteRequest는 요청 헤더를 magic/version/opcode와 가변 길이 메타데이터
+// 블록으로 인코딩하여 w에 쓴다.
+func WriteRequest(w io.Writer, req RequestHeader) error {
+	var meta bytes.Buffer
+	if err := wire.WriteString(&meta, req.Meta.Filename); err != nil {
+		return err
+	}
+	if err := binary.Write(&meta, binary.BigEndian, req.Meta.Size); err != nil {
+		return err
+	}
+	if err := binary.Write(&meta, binary.BigEndian, req.Meta.ModTime); err != nil {
+		return err
+	}
+	if err := wire.WriteString(&meta, req.Meta.SHA256); err != nil {
+		return err
+	}
+	if err := wire.WriteString(&meta, req.Meta.MimeType); err != nil {
+		return err
+	}
+	if err := wire.WriteString(&meta, req.Meta.JobID); err != nil {
+		return err
+	}
+	if err := binary.Write(&meta, binary.BigEndian, req.Meta.ChunkIndex); err != nil {
+		return err
+	}
+	if err := wire.WriteString(&meta, req.Meta.DestKey); err != nil {
+		return err
+	}
+
+	var hdr bytes.Buffer
+	if err := binary.Write(&hdr, binary.BigEndian, Magic); err != nil {
+		return err
+	}
+	if err := binary.Write(&hdr, binary.BigEndian, Version); err != nil {
+		return err
+	}
+	if err := binary.Write(&hdr, binary.BigEndian, req.Opcode); err != nil {
+		return err
+	}
+	if err := wire.WriteUvarint(&hdr, uint64(meta.Len())); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(hdr.Bytes()); err != nil {
+		return err
+	}
+	_, err := w.Write(meta.Bytes())
+	return err
+}
+
+// ReadRequest는 WriteRequest의 반대 동작을 수행하여 요청 헤더를 읽는다.
+func ReadRequest(r io.Reader) (RequestHeader, error) {
+	var req RequestHeader
+
+	var magic uint32
+	if err := binary.Read(r, binary.BigEndian, &magic); err != nil {
+		return req, fmt.Errorf("매직 넘버 읽기 오류: %w", err)
+	}
+	if magic != Magic {
+		return req, fmt.Errorf("알 수 없는 프로토콜 매직 넘버: %#x", magic)
+	}
+
+	var version uint8
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return req, fmt.Errorf("버전 읽기 오류: %w", err)
+	}
+	if version != Version {
+		return req, fmt.Errorf("지원하지 않는 프로토콜 버전: %d", version)
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &req.Opcode); err != nil {
+		return req, fmt.Errorf("opcode 읽기 오류: %w", err)
+	}
+
+	metaLen, err := wire.ReadUvarint(r)
+	if err != nil {
+		return req, fmt.Errorf("메타데이터 길이 읽기 오류: %w", err)
+	}
+	if metaLen > wire.MaxLen {
+		return req, fmt.Errorf("메타데이터 길이가 한도를 넘었습니다: %d > %d", metaLen, wire.MaxLen)
+	}
+
+	meta := io.LimitReader(r, int64(metaLen))
+	if req.Meta.Filename, err = wire.ReadString(meta); err != nil {
+		return req, fmt.Errorf("파일명 읽기 오류: %w", err)
+	}
+	if err := binary.Read(meta, binary.BigEndian, &req.Meta.Size); err != nil {
+		return req, fmt.Errorf("크기 읽기 오류: %w", err)
+	}
+	if err := binary.Read(meta, binary.BigEndian, &req.Meta.ModTime); err != nil {
+		return req, fmt.Errorf("수정시각 읽기 오류: %w", err)
+	}
+	if req.Meta.SHA256, err = wire.ReadString(meta); err != nil {
+		return req, fmt.Errorf("sha256 읽기 오류: %w", err)
+	}
+	if req.Meta.MimeType, err = wire.ReadString(meta); err != nil {
+		return req, fmt.Errorf("mime type 읽기 오류: %w", err)
+	}
+	if req.Meta.JobID, err = wire.ReadString(meta); err != nil {
+		return req, fmt.Errorf("job id 읽기 오류: %w", err)
+	}
+	if err := binary.Read(meta, binary.BigEndian, &req.Meta.ChunkIndex); err != nil {
+		return req, fmt.Errorf("chunk index 읽기 오류: %w", err)
+	}
+	if req.Meta.DestKey, err = wire.ReadString(meta); err != nil {
+		return req, fmt.Errorf("dest key 읽기 오류: %w", err)
+	}
+
+	return req, nil
+}
+
+// WriteResponse는 응답 헤더를 status/message/payload 길이 순으로 인코딩하여 w에 쓴다.
+func WriteResponse(w io.Writer, resp ResponseHeader) error {
+	if err := binary.Write(w, binary.BigEndian, resp.Status); err != nil {
+		return err
+	}
+	if err := wire.WriteString(w, resp.Message); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.BigEndian, resp.PayloadLen)
+}
+
+// ReadResponse는 WriteResponse의 반대 동작을 수행하여 응답 헤더를 읽는다.
+func ReadResponse(r io.Reader) (ResponseHeader, error) {
+	var resp ResponseHeader
+	if err := binary.Read(r, binary.BigEndian, &resp.Status); err != nil {
+		return resp, fmt.Errorf("status 읽기 오류: %w", err)
+	}
+	msg, err := wire.ReadString(r)
+	if err != nil {
+		return resp, fmt.Errorf("message 읽기 오류: %w", err)
+	}
+	resp.Message = msg
+	if err := binary.Read(r, binary.BigEndian, &resp.PayloadLen); err != nil {
+		return resp, fmt.Errorf("payload 길이 읽기 오류: %w", err)
+	}
+	return resp, nil
+}
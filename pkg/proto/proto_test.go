@@ -0,0 +1,90 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"projectno1/pkg/wire"
+)
+
+func TestRequestRoundTrip(t *testing.T) {
+	req := RequestHeader{
+		Opcode: OpUpload,
+		Meta: Metadata{
+			Filename:   "a.txt",
+			Size:       42,
+			ModTime:    1700000000,
+			SHA256:     "deadbeef",
+			MimeType:   "text/plain",
+			JobID:      "job-1",
+			ChunkIndex: 3,
+			DestKey:    "dst.txt",
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRequest(&buf, req); err != nil {
+		t.Fatalf("WriteRequest: %v", err)
+	}
+
+	got, err := ReadRequest(&buf)
+	if err != nil {
+		t.Fatalf("ReadRequest: %v", err)
+	}
+	if got != req {
+		t.Errorf("round trip = %+v, want %+v", got, req)
+	}
+}
+
+func TestResponseRoundTrip(t *testing.T) {
+	resp := ResponseHeader{Status: StatusOK, Message: "ok", PayloadLen: 7}
+
+	var buf bytes.Buffer
+	if err := WriteResponse(&buf, resp); err != nil {
+		t.Fatalf("WriteResponse: %v", err)
+	}
+
+	got, err := ReadResponse(&buf)
+	if err != nil {
+		t.Fatalf("ReadResponse: %v", err)
+	}
+	if got != resp {
+		t.Errorf("round trip = %+v, want %+v", got, resp)
+	}
+}
+
+func TestReadRequestRejectsUnknownMagic(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRequest(&buf, RequestHeader{Opcode: OpUpload}); err != nil {
+		t.Fatalf("WriteRequest: %v", err)
+	}
+	corrupted := buf.Bytes()
+	corrupted[0] ^= 0xff
+
+	if _, err := ReadRequest(bytes.NewReader(corrupted)); err == nil {
+		t.Error("ReadRequest with corrupted magic = nil error, want error")
+	}
+}
+
+func TestReadRequestRejectsOversizedMetaLen(t *testing.T) {
+	var hdr bytes.Buffer
+	hdr.Write([]byte{0x50, 0x4e, 0x4f, 0x31}) // Magic
+	hdr.WriteByte(byte(Version))
+	hdr.WriteByte(byte(OpUpload))
+	if err := wire.WriteUvarint(&hdr, wire.MaxLen+1); err != nil {
+		t.Fatalf("WriteUvarint: %v", err)
+	}
+
+	if _, err := ReadRequest(&hdr); err == nil {
+		t.Error("ReadRequest with metaLen > MaxLen = nil error, want error")
+	}
+}
+
+func TestOpcodeString(t *testing.T) {
+	if got := OpUpload.String(); got != "UPLOAD" {
+		t.Errorf("OpUpload.String() = %q, want UPLOAD", got)
+	}
+	if got := Opcode(255).String(); got != "OPCODE(255)" {
+		t.Errorf("Opcode(255).String() = %q, want OPCODE(255)", got)
+	}
+}
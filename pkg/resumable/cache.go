@@ -0,0 +1,166 @@
+// Package resumable은 대용량 파일을 4MiB 청크 단위로 나누어 업로드하고,
+// 중간에 끊겨도 이미 전송한 청크를 다시 보내지 않도록 진행 상태를
+// ~/.projectno1/qupload/<jobid>.cache 파일에 JSON Lines로 남긴다.
+package resumable
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ChunkSize는 업로드를 나누는 기본 청크 크기이다.
+const ChunkSize = 4 * 1024 * 1024
+
+// ChunkState는 단일 청크의 진행 상태를 나타낸다.
+type ChunkState struct {
+	Index     int    `json:"index"`
+	Offset    int64  `json:"offset"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
+	BlockID   string `json:"block_id"`
+	Committed bool   `json:"committed"`
+}
+
+// Cache는 하나의 업로드 작업(jobID)에 대한 청크 진행 상태를 담는다.
+// 청크는 여러 워커 고루틴에서 동시에 업로드되므로 mu가 Chunks와 파일
+// 접근을 보호한다.
+type Cache struct {
+	mu     sync.Mutex
+	path   string
+	JobID  string
+	Chunks map[int]ChunkState
+}
+
+// JobID는 원본 경로와 서버 버킷으로부터 업로드 작업을 식별하는 id를 계산한다.
+// 같은 파일을 같은 버킷에 다시 업로드하면 항상 같은 jobID가 나오므로,
+// 캐시 파일을 재사용해 이어받기가 가능하다.
+func JobID(srcPath, serverBucket string) string {
+	sum := sha256.Sum256([]byte(srcPath + ":" + serverBucket))
+	return hex.EncodeToString(sum[:])
+}
+
+// CacheDir은 청크 진행 상태 파일들이 저장되는 디렉토리를 반환하고,
+// 없으면 생성한다.
+func CacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("홈 디렉토리 조회 실패: %w", err)
+	}
+	dir := filepath.Join(home, ".projectno1", "qupload")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("캐시 디렉토리 생성 실패: %w", err)
+	}
+	return dir, nil
+}
+
+// LoadCache는 jobID에 해당하는 캐시 파일을 읽는다. 파일이 없으면 빈 Cache를
+// 반환한다 (처음 업로드하는 경우).
+func LoadCache(jobID string) (*Cache, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	c := &Cache{
+		path:   filepath.Join(dir, jobID+".cache"),
+		JobID:  jobID,
+		Chunks: make(map[int]ChunkState),
+	}
+
+	f, err := os.Open(c.path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("캐시 파일 열기 실패: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var cs ChunkState
+		if err := json.Unmarshal(line, &cs); err != nil {
+			return nil, fmt.Errorf("캐시 라인 파싱 실패: %w", err)
+		}
+		c.Chunks[cs.Index] = cs
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("캐시 파일 읽기 실패: %w", err)
+	}
+
+	return c, nil
+}
+
+// Put은 청크 상태를 갱신하고 즉시 디스크에 반영한다. 병렬 업로드 워커들이
+// 동시에 호출해도 안전하다.
+func (c *Cache) Put(cs ChunkState) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Chunks[cs.Index] = cs
+	return c.save()
+}
+
+// Committed는 서버가 이미 커밋했다고 확인한 청크 인덱스 집합으로
+// 로컬 캐시를 동기화한다.
+func (c *Cache) Committed(indexes map[int]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for idx, cs := range c.Chunks {
+		cs.Committed = indexes[idx]
+		c.Chunks[idx] = cs
+	}
+	for idx, ok := range indexes {
+		if _, exists := c.Chunks[idx]; ok && !exists {
+			c.Chunks[idx] = ChunkState{Index: idx, Committed: true}
+		}
+	}
+}
+
+// Remaining은 아직 커밋되지 않은 청크 인덱스를 반환한다.
+func (c *Cache) Remaining(total int) []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []int
+	for i := 0; i < total; i++ {
+		if cs, ok := c.Chunks[i]; ok && cs.Committed {
+			continue
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// save는 현재 상태를 JSON Lines 형식으로 캐시 파일에 다시 쓴다.
+func (c *Cache) save() error {
+	f, err := os.Create(c.path)
+	if err != nil {
+		return fmt.Errorf("캐시 파일 생성 실패: %w", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, cs := range c.Chunks {
+		if err := enc.Encode(cs); err != nil {
+			return fmt.Errorf("캐시 라인 쓰기 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// Remove는 업로드가 성공적으로 커밋된 뒤 캐시 파일을 삭제한다.
+func (c *Cache) Remove() error {
+	err := os.Remove(c.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package resumable
+
+import (
+	"testing"
+)
+
+func TestPlanChunks(t *testing.T) {
+	chunks := PlanChunks(ChunkSize*2 + 10)
+	if len(chunks) != 3 {
+		t.Fatalf("len(chunks) = %d, want 3", len(chunks))
+	}
+	if chunks[0].Offset != 0 || chunks[0].Size != ChunkSize {
+		t.Errorf("chunks[0] = %+v, want Offset=0 Size=%d", chunks[0], ChunkSize)
+	}
+	if chunks[1].Offset != ChunkSize || chunks[1].Size != ChunkSize {
+		t.Errorf("chunks[1] = %+v, want Offset=%d Size=%d", chunks[1], ChunkSize, ChunkSize)
+	}
+	if chunks[2].Offset != ChunkSize*2 || chunks[2].Size != 10 {
+		t.Errorf("chunks[2] = %+v, want Offset=%d Size=10", chunks[2], ChunkSize*2)
+	}
+}
+
+func TestPlanChunksEmptyFile(t *testing.T) {
+	chunks := PlanChunks(0)
+	if len(chunks) != 1 || chunks[0].Size != 0 {
+		t.Errorf("PlanChunks(0) = %+v, want a single zero-size chunk", chunks)
+	}
+}
+
+func newTestCache(t *testing.T) *Cache {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	c, err := LoadCache("test-job")
+	if err != nil {
+		t.Fatalf("LoadCache: %v", err)
+	}
+	return c
+}
+
+func TestCachePutAndRemaining(t *testing.T) {
+	c := newTestCache(t)
+
+	if err := c.Put(ChunkState{Index: 0, Size: ChunkSize, Committed: true}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := c.Put(ChunkState{Index: 1, Size: ChunkSize, Committed: false}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	remaining := c.Remaining(3)
+	if len(remaining) != 2 || remaining[0] != 1 || remaining[1] != 2 {
+		t.Errorf("Remaining(3) = %v, want [1 2]", remaining)
+	}
+}
+
+func TestCacheCommitted(t *testing.T) {
+	c := newTestCache(t)
+
+	if err := c.Put(ChunkState{Index: 0}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := c.Put(ChunkState{Index: 1}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	c.Committed(map[int]bool{0: true})
+
+	remaining := c.Remaining(2)
+	if len(remaining) != 1 || remaining[0] != 1 {
+		t.Errorf("Remaining(2) after Committed({0:true}) = %v, want [1]", remaining)
+	}
+}
+
+func TestCachePersistsAcrossLoad(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	c, err := LoadCache("persist-job")
+	if err != nil {
+		t.Fatalf("LoadCache: %v", err)
+	}
+	if err := c.Put(ChunkState{Index: 0, SHA256: "abc", Committed: true}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	reloaded, err := LoadCache("persist-job")
+	if err != nil {
+		t.Fatalf("LoadCache (reload): %v", err)
+	}
+	cs, ok := reloaded.Chunks[0]
+	if !ok || cs.SHA256 != "abc" || !cs.Committed {
+		t.Errorf("reloaded Chunks[0] = %+v, ok=%v, want SHA256=abc Committed=true", cs, ok)
+	}
+}
+
+func TestCacheRemove(t *testing.T) {
+	c := newTestCache(t)
+	if err := c.Put(ChunkState{Index: 0}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := c.Remove(); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	// 캐시 파일이 이미 지워진 뒤에도 다시 지우는 건 오류가 아니다.
+	if err := c.Remove(); err != nil {
+		t.Errorf("Remove (second time) = %v, want nil", err)
+	}
+}
+
+func TestJobIDStable(t *testing.T) {
+	a := JobID("/tmp/file.txt", "bucket")
+	b := JobID("/tmp/file.txt", "bucket")
+	if a != b {
+		t.Errorf("JobID not stable: %q != %q", a, b)
+	}
+	if c := JobID("/tmp/file.txt", "other-bucket"); c == a {
+		t.Errorf("JobID(%q, other-bucket) collided with JobID(%q, bucket)", "/tmp/file.txt", "/tmp/file.txt")
+	}
+}
@@ -0,0 +1,126 @@
+package resumable
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Chunk는 스케줄러가 워커에게 넘기는 업로드 작업 단위이다.
+type Chunk struct {
+	Index  int
+	Offset int64
+	Size   int64
+}
+
+// PlanChunks는 파일 크기를 ChunkSize 단위로 나눈 Chunk 목록을 만든다.
+func PlanChunks(fileSize int64) []Chunk {
+	if fileSize == 0 {
+		return []Chunk{{Index: 0, Offset: 0, Size: 0}}
+	}
+
+	var chunks []Chunk
+	for offset, idx := int64(0), 0; offset < fileSize; offset, idx = offset+ChunkSize, idx+1 {
+		size := int64(ChunkSize)
+		if remaining := fileSize - offset; remaining < size {
+			size = remaining
+		}
+		chunks = append(chunks, Chunk{Index: idx, Offset: offset, Size: size})
+	}
+	return chunks
+}
+
+// UploadFunc는 청크 하나를 서버에 올리는 함수이다. 스케줄러는 실패 시
+// 지수 백오프를 적용하며 첫 시도를 포함해 최대 MaxAttempts번 시도한다.
+type UploadFunc func(ctx context.Context, chunk Chunk) error
+
+// Scheduler는 Concurrency개의 워커로 청크 업로드를 병렬 실행한다.
+type Scheduler struct {
+	Concurrency int
+	MaxAttempts int
+	BaseDelay   time.Duration
+}
+
+// NewScheduler는 concurrency 워커, 기본 재시도 정책(최대 5회, 200ms 기준
+// 지수 백오프)을 갖는 Scheduler를 만든다. concurrency가 1 미만이면 1로
+// 보정한다.
+func NewScheduler(concurrency int) *Scheduler {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Scheduler{
+		Concurrency: concurrency,
+		MaxAttempts: 5,
+		BaseDelay:   200 * time.Millisecond,
+	}
+}
+
+// Run은 chunks를 워커 풀에 분배해 upload를 호출한다. 하나라도 모든
+// 재시도를 소진하고 실패하면 나머지 청크는 바로 중단되고 첫 에러가
+// 반환된다.
+func (s *Scheduler) Run(ctx context.Context, chunks []Chunk, upload UploadFunc) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan Chunk)
+	errs := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range jobs {
+				if err := s.uploadWithRetry(ctx, chunk, upload); err != nil {
+					select {
+					case errs <- fmt.Errorf("청크 %d 업로드 실패: %w", chunk.Index, err):
+						cancel()
+					default:
+					}
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, chunk := range chunks {
+		select {
+		case jobs <- chunk:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return ctx.Err()
+	}
+}
+
+// uploadWithRetry는 upload(chunk)를 지수 백오프로 최대 MaxAttempts번 시도한다.
+func (s *Scheduler) uploadWithRetry(ctx context.Context, chunk Chunk, upload UploadFunc) error {
+	var lastErr error
+	for attempt := 0; attempt < s.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := s.BaseDelay * time.Duration(math.Pow(2, float64(attempt-1)))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		lastErr = upload(ctx, chunk)
+		if lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
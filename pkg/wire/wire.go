@@ -0,0 +1,74 @@
+// Package wire는 이 저장소의 바이너리 프로토콜들이 공통으로 쓰는 길이
+// 프리픽스 문자열/가변 길이 정수 인코딩을 제공한다. pkg/proto(요청/응답
+// 프레임)와 pkg/manifest(트리 매니페스트 프레임)가 같은 인코딩을 쓰면서도
+// 서로 독립적인 패키지로 남을 수 있도록 이 원시 타입들만 여기서 공유한다.
+package wire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// MaxLen은 ReadString이나 길이 프리픽스를 읽는 다른 디코더가 단일
+// 필드/항목 수로 받아들이는 최대값이다. 이 한도가 없으면 상대방이 보낸
+// 조작된 길이값 하나로 make()가 수백 GB를 할당하려다 프로세스가 죽을 수
+// 있다.
+const MaxLen = 16 << 20 // 16 MiB
+
+// WriteString은 s를 uvarint 길이 프리픽스와 함께 w에 쓴다.
+func WriteString(w io.Writer, s string) error {
+	if err := WriteUvarint(w, uint64(len(s))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+// ReadString은 WriteString의 반대 동작을 수행한다. 길이가 MaxLen을 넘으면
+// 할당을 시도하지 않고 오류를 반환한다.
+func ReadString(r io.Reader) (string, error) {
+	n, err := ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	if n > MaxLen {
+		return "", fmt.Errorf("wire: 문자열 길이가 한도를 넘었습니다: %d > %d", n, MaxLen)
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// WriteUvarint는 v를 가변 길이 정수로 인코딩하여 w에 쓴다.
+func WriteUvarint(w io.Writer, v uint64) error {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, v)
+	_, err := w.Write(buf[:n])
+	return err
+}
+
+// ReadUvarint는 WriteUvarint의 반대 동작을 수행한다.
+func ReadUvarint(r io.Reader) (uint64, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: r}
+	}
+	return binary.ReadUvarint(br)
+}
+
+// byteReader는 binary.ReadUvarint가 요구하는 io.ByteReader 인터페이스로
+// 일반 io.Reader를 감싼다.
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (b *byteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(b.r, b.buf[:]); err != nil {
+		return 0, err
+	}
+	return b.buf[0], nil
+}
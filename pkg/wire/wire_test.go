@@ -0,0 +1,49 @@
+package wire
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStringRoundTrip(t *testing.T) {
+	for _, s := range []string{"", "hello", strings.Repeat("a", 1000)} {
+		var buf bytes.Buffer
+		if err := WriteString(&buf, s); err != nil {
+			t.Fatalf("WriteString(%q): %v", s, err)
+		}
+		got, err := ReadString(&buf)
+		if err != nil {
+			t.Fatalf("ReadString after WriteString(%q): %v", s, err)
+		}
+		if got != s {
+			t.Errorf("round trip = %q, want %q", got, s)
+		}
+	}
+}
+
+func TestUvarintRoundTrip(t *testing.T) {
+	for _, v := range []uint64{0, 1, 127, 128, 1 << 32, ^uint64(0)} {
+		var buf bytes.Buffer
+		if err := WriteUvarint(&buf, v); err != nil {
+			t.Fatalf("WriteUvarint(%d): %v", v, err)
+		}
+		got, err := ReadUvarint(&buf)
+		if err != nil {
+			t.Fatalf("ReadUvarint after WriteUvarint(%d): %v", v, err)
+		}
+		if got != v {
+			t.Errorf("round trip = %d, want %d", got, v)
+		}
+	}
+}
+
+func TestReadStringRejectsOversizedLength(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteUvarint(&buf, MaxLen+1); err != nil {
+		t.Fatalf("WriteUvarint: %v", err)
+	}
+	if _, err := ReadString(&buf); err == nil {
+		t.Error("ReadString with length > MaxLen = nil error, want error")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package main
+
+// quarantineFile은 격리 확장 속성이나 대체 데이터 스트림을 지원하지 않는
+// 플랫폼에서는 아무 동작도 하지 않는다.
+func quarantineFile(path string) error {
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// quarantineFile은 윈도우에서 다운로드한 파일에 Zone.Identifier 대체 데이터
+// 스트림(ADS)을 붙여, 인터넷(Zone 3)에서 받은 파일이라는 표시(Mark-of-the-Web)를
+// 남긴다. 탐색기와 SmartScreen은 이 표시를 보고 실행 전 경고를 띄운다.
+func quarantineFile(path string) error {
+	content := "[ZoneTransfer]\r\nZoneId=3\r\nHostUrl=projectno1://" + serverAddr + "\r\n"
+	return os.WriteFile(path+":Zone.Identifier", []byte(content), 0644)
+}
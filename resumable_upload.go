@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/quic-go/quic-go"
+
+	"projectno1/pkg/proto"
+	"projectno1/pkg/resumable"
+)
+
+// defaultThreadCount는 -threads가 주어지지 않았을 때 이어받기 업로드에서
+// 동시에 전송할 청크 워커 수이다.
+const defaultThreadCount = 4
+
+// uploadFileResumable은 path를 ChunkSize 단위로 나누어 이어받기 가능한
+// 방식으로 업로드한다. RESUME으로 서버가 이미 커밋한 청크를 확인하고, 남은
+// 청크만 threads개의 스트림으로 병렬 전송한 뒤 COMMIT으로 조립을 요청한다.
+// 중간에 중단되어도 ~/.projectno1/qupload에 남은 캐시로 다음 실행에서
+// 이어진다. bucket은 jobID 계산에 쓰이는 업로드 대상 구분값으로, `file cp`
+// 에서는 <peer>: 인자가 된다. threads가 1 미만이면 defaultThreadCount를 쓴다.
+//
+// 파일 전체를 메모리에 올리지 않기 위해, 파일을 한 번만 열어 전체 sha256
+// 계산에는 순차 io.Copy를, 청크별 전송에는 os.File.ReadAt을 쓴다. ReadAt은
+// 파일의 읽기 위치(offset)와 무관하게 동작하므로 청크 워커들이 같은 *os.File을
+// 동시에 ReadAt해도 안전하다.
+func uploadFileResumable(conn quic.Connection, path, bucket string, threads int) error {
+	if threads < 1 {
+		threads = defaultThreadCount
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("파일 열기 오류: %w", err)
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("파일 정보 조회 오류: %w", err)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return fmt.Errorf("sha256 계산 오류: %w", err)
+	}
+
+	fileName := filepath.Base(path)
+	meta := proto.Metadata{
+		Filename: fileName,
+		Size:     fileInfo.Size(),
+		ModTime:  fileInfo.ModTime().Unix(),
+		SHA256:   hex.EncodeToString(h.Sum(nil)),
+		MimeType: mimeTypeFor(fileName),
+		JobID:    resumable.JobID(path, bucket),
+	}
+
+	cache, err := resumable.LoadCache(meta.JobID)
+	if err != nil {
+		return fmt.Errorf("캐시 로드 오류: %w", err)
+	}
+
+	committed, err := resumeJob(conn, meta)
+	if err != nil {
+		return fmt.Errorf("RESUME 요청 실패: %w", err)
+	}
+	cache.Committed(committed)
+
+	chunks := resumable.PlanChunks(meta.Size)
+	byIndex := make(map[int]resumable.Chunk, len(chunks))
+	for _, c := range chunks {
+		byIndex[c.Index] = c
+	}
+
+	remaining := cache.Remaining(len(chunks))
+	if len(remaining) == 0 {
+		fmt.Println("이미 모든 청크가 업로드되어 있습니다. COMMIT만 진행합니다.")
+	} else {
+		fmt.Printf("%d개 청크 중 %d개를 업로드합니다 (동시 %d개).\n", len(chunks), len(remaining), threads)
+	}
+
+	toUpload := make([]resumable.Chunk, 0, len(remaining))
+	for _, idx := range remaining {
+		toUpload = append(toUpload, byIndex[idx])
+	}
+
+	sched := resumable.NewScheduler(threads)
+	upload := func(ctx context.Context, chunk resumable.Chunk) error {
+		return uploadChunk(ctx, conn, meta, cache, file, chunk)
+	}
+	if err := sched.Run(context.Background(), toUpload, upload); err != nil {
+		return fmt.Errorf("청크 업로드 실패: %w", err)
+	}
+
+	if err := commitJob(conn, meta); err != nil {
+		return fmt.Errorf("COMMIT 요청 실패: %w", err)
+	}
+
+	if err := cache.Remove(); err != nil {
+		fmt.Fprintf(os.Stderr, "캐시 파일 정리 실패: %v\n", err)
+	}
+
+	fmt.Printf("'%s' 파일 이어받기 업로드 완료: %d 바이트 전송\n", fileName, meta.Size)
+	return nil
+}
+
+// resumeJob은 RESUME 프레임을 보내 서버가 이미 커밋한 청크 인덱스 집합을 받아온다.
+// 응답 페이로드는 파일 목록(LIST)과 동일하게 줄바꿈으로 구분된 청크 인덱스 텍스트이다.
+func resumeJob(conn quic.Connection, meta proto.Metadata) (map[int]bool, error) {
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+	defer stream.Close()
+
+	req := proto.RequestHeader{Opcode: proto.OpResume, Meta: meta}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		return nil, fmt.Errorf("요청 전송 오류: %w", err)
+	}
+	if err := stream.Close(); err != nil {
+		return nil, fmt.Errorf("스트림 닫기 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return nil, fmt.Errorf("응답 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return nil, fmt.Errorf("서버 오류: %s", resp.Message)
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(stream, resp.PayloadLen))
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("커밋된 청크 목록 읽기 오류: %w", err)
+	}
+
+	committed := make(map[int]bool)
+	for _, line := range strings.Split(strings.TrimSpace(string(payload)), "\n") {
+		if line == "" {
+			continue
+		}
+		idx, err := strconv.Atoi(line)
+		if err != nil {
+			continue
+		}
+		committed[idx] = true
+	}
+	return committed, nil
+}
+
+// uploadChunk는 청크 하나를 독립된 스트림으로 전송하고 서버 응답을 검증한 뒤
+// 캐시에 커밋 상태를 기록한다. 재시도는 Scheduler가 처리하므로 여기서는
+// 실패를 그대로 반환하기만 하면 된다. file.ReadAt으로 해당 청크만 읽으므로
+// 파일 전체를 메모리에 들고 있을 필요가 없다.
+func uploadChunk(ctx context.Context, conn quic.Connection, fileMeta proto.Metadata, cache *resumable.Cache, file *os.File, chunk resumable.Chunk) error {
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		return fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+	defer stream.Close()
+
+	chunkData := make([]byte, chunk.Size)
+	if _, err := file.ReadAt(chunkData, chunk.Offset); err != nil {
+		return fmt.Errorf("청크 읽기 오류: %w", err)
+	}
+	chunkSum := sha256.Sum256(chunkData)
+	chunkSHA256 := hex.EncodeToString(chunkSum[:])
+
+	req := proto.RequestHeader{
+		Opcode: proto.OpUpload,
+		Meta: proto.Metadata{
+			Filename:   fileMeta.Filename,
+			Size:       chunk.Size,
+			SHA256:     chunkSHA256,
+			MimeType:   fileMeta.MimeType,
+			JobID:      fileMeta.JobID,
+			ChunkIndex: int32(chunk.Index),
+		},
+	}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		return fmt.Errorf("요청 전송 오류: %w", err)
+	}
+	if _, err := stream.Write(chunkData); err != nil {
+		return fmt.Errorf("청크 전송 오류: %w", err)
+	}
+	if err := stream.Close(); err != nil {
+		return fmt.Errorf("스트림 닫기 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return fmt.Errorf("응답 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return fmt.Errorf("청크 %d 서버 오류: %s", chunk.Index, resp.Message)
+	}
+
+	return cache.Put(resumable.ChunkState{
+		Index:     chunk.Index,
+		Offset:    chunk.Offset,
+		Size:      chunk.Size,
+		SHA256:    chunkSHA256,
+		BlockID:   resp.Message,
+		Committed: true,
+	})
+}
+
+// commitJob은 전체 파일 sha256을 담은 COMMIT 프레임을 보내 서버가 청크를
+// 조립하고 무결성을 검증하도록 요청한다.
+func commitJob(conn quic.Connection, meta proto.Metadata) error {
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+	defer stream.Close()
+
+	req := proto.RequestHeader{Opcode: proto.OpCommit, Meta: meta}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		return fmt.Errorf("요청 전송 오류: %w", err)
+	}
+	if err := stream.Close(); err != nil {
+		return fmt.Errorf("스트림 닫기 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return fmt.Errorf("응답 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return fmt.Errorf("서버 오류: %s", resp.Message)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/quic-go/quic-go"
+
+	"projectno1/pkg/proto"
+)
+
+// copyRemoteFile은 OpCopy 프레임을 보내, 다시 업로드하지 않고 서버 인박스
+// 안에서 srcKey를 dstKey로 복사하도록 요청한다.
+func copyRemoteFile(conn quic.Connection, srcKey, dstKey string) error {
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+	defer stream.Close()
+
+	req := proto.RequestHeader{
+		Opcode: proto.OpCopy,
+		Meta:   proto.Metadata{Filename: srcKey, DestKey: dstKey},
+	}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		return fmt.Errorf("요청 전송 오류: %w", err)
+	}
+	if err := stream.Close(); err != nil {
+		return fmt.Errorf("스트림 닫기 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return fmt.Errorf("응답 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return fmt.Errorf("'%s' -> '%s' 복사 실패: %s", srcKey, dstKey, resp.Message)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/quic-go/quic-go"
+
+	"projectno1/pkg/manifest"
+	"projectno1/pkg/proto"
+)
+
+// treeUpload는 localDir 아래의 전체 트리를 업로드한다. 먼저 별도의 제어
+// 스트림으로 OpTreeUp 요청과 함께 매니페스트를 보내고, 서버가 돌려주는
+// presence 비트맵(경로+sha256 기준으로 이미 갖고 있는 항목 표시)으로 빠진
+// 파일만 추려낸 뒤, 각 파일을 자신의 스트림으로 최대 treeConcurrency개씩
+// 동시에 업로드한다.
+func treeUpload(conn quic.Connection, localDir, bucket string) error {
+	mf, err := manifest.Build(localDir)
+	if err != nil {
+		return fmt.Errorf("매니페스트 생성 오류: %w", err)
+	}
+
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+
+	req := proto.RequestHeader{Opcode: proto.OpTreeUp, Meta: proto.Metadata{Filename: bucket}}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		stream.Close()
+		return fmt.Errorf("요청 전송 오류: %w", err)
+	}
+	if err := mf.Encode(stream); err != nil {
+		stream.Close()
+		return fmt.Errorf("매니페스트 전송 오류: %w", err)
+	}
+	if err := stream.Close(); err != nil {
+		return fmt.Errorf("스트림 닫기 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return fmt.Errorf("응답 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return fmt.Errorf("서버 오류: %s", resp.Message)
+	}
+
+	presence, err := manifest.DecodePresence(io.LimitReader(stream, resp.PayloadLen))
+	if err != nil {
+		return fmt.Errorf("presence 비트맵 읽기 오류: %w", err)
+	}
+
+	missing := mf.Missing(presence)
+	if len(missing) == 0 {
+		fmt.Println("업로드할 새 파일이 없습니다.")
+		return nil
+	}
+	fmt.Printf("%d개 항목 중 %d개를 업로드합니다 (동시 %d개).\n", len(mf.Entries), len(missing), treeConcurrency)
+
+	return runPool(treeConcurrency, len(missing), func(i int) error {
+		entry := missing[i]
+		localPath := filepath.Join(localDir, filepath.FromSlash(entry.RelPath))
+		if err := uploadTreeFile(conn, bucket, entry.RelPath, localPath); err != nil {
+			return fmt.Errorf("'%s' 업로드 실패: %w", entry.RelPath, err)
+		}
+		return nil
+	})
+}
+
+// uploadTreeFile은 트리 업로드 중 파일 하나를 자신만의 스트림으로 올린다.
+// JobID에는 트리 전체를 묶는 bucket을 실어, 서버가 이 파일이 어느 트리에
+// 속하는지 알 수 있게 한다.
+func uploadTreeFile(conn quic.Connection, bucket, relPath, localPath string) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("파일 열기 오류: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("파일 정보 조회 오류: %w", err)
+	}
+
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+	defer stream.Close()
+
+	req := proto.RequestHeader{
+		Opcode: proto.OpUpload,
+		Meta: proto.Metadata{
+			Filename: relPath,
+			Size:     info.Size(),
+			ModTime:  info.ModTime().Unix(),
+			MimeType: mimeTypeFor(relPath),
+			JobID:    bucket,
+		},
+	}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		return fmt.Errorf("요청 전송 오류: %w", err)
+	}
+	if _, err := io.Copy(stream, file); err != nil {
+		return fmt.Errorf("파일 전송 오류: %w", err)
+	}
+	if err := stream.Close(); err != nil {
+		return fmt.Errorf("스트림 닫기 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return fmt.Errorf("응답 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return fmt.Errorf("%s", resp.Message)
+	}
+	return nil
+}
+
+// treeDownload는 서버에 prefix 아래 트리의 매니페스트를 요청하고, 받은 모든
+// 항목을 destDir 아래에 상대 경로 구조를 보존하며 최대 treeConcurrency개씩
+// 동시에 내려받는다.
+func treeDownload(conn quic.Connection, prefix, destDir string) error {
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+
+	req := proto.RequestHeader{Opcode: proto.OpTreeDown, Meta: proto.Metadata{Filename: prefix}}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		stream.Close()
+		return fmt.Errorf("요청 전송 오류: %w", err)
+	}
+	if err := stream.Close(); err != nil {
+		return fmt.Errorf("스트림 닫기 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return fmt.Errorf("응답 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return fmt.Errorf("서버 오류: %s", resp.Message)
+	}
+
+	mf, err := manifest.Decode(io.LimitReader(stream, resp.PayloadLen))
+	if err != nil {
+		return fmt.Errorf("매니페스트 읽기 오류: %w", err)
+	}
+	if len(mf.Entries) == 0 {
+		fmt.Println("내려받을 파일이 없습니다.")
+		return nil
+	}
+	fmt.Printf("%d개 파일을 내려받습니다 (동시 %d개).\n", len(mf.Entries), treeConcurrency)
+
+	return runPool(treeConcurrency, len(mf.Entries), func(i int) error {
+		entry := mf.Entries[i]
+		if err := validateTreePath(entry.RelPath); err != nil {
+			fmt.Fprintf(os.Stderr, "'%s' 건너뜀: %v\n", entry.RelPath, err)
+			return nil
+		}
+
+		destPath := filepath.Join(destDir, filepath.FromSlash(entry.RelPath))
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return fmt.Errorf("'%s' 디렉토리 생성 실패: %w", entry.RelPath, err)
+		}
+		if err := downloadTreeFile(conn, entry.RelPath, destPath); err != nil {
+			return fmt.Errorf("'%s' 다운로드 실패: %w", entry.RelPath, err)
+		}
+		return nil
+	})
+}
+
+// downloadTreeFile은 트리 다운로드 중 파일 하나를 자신만의 스트림으로
+// 받는다.
+func downloadTreeFile(conn quic.Connection, relPath, destPath string) error {
+	stream, err := conn.OpenStreamSync(context.Background())
+	if err != nil {
+		return fmt.Errorf("스트림 열기 실패: %w", err)
+	}
+	defer stream.Close()
+
+	req := proto.RequestHeader{Opcode: proto.OpDownload, Meta: proto.Metadata{Filename: relPath}}
+	if err := proto.WriteRequest(stream, req); err != nil {
+		return fmt.Errorf("요청 전송 오류: %w", err)
+	}
+
+	resp, err := proto.ReadResponse(stream)
+	if err != nil {
+		return fmt.Errorf("응답 헤더 읽기 오류: %w", err)
+	}
+	if resp.Status != proto.StatusOK {
+		return fmt.Errorf("%s", resp.Message)
+	}
+
+	file, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("파일 생성 오류: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, io.LimitReader(stream, resp.PayloadLen)); err != nil && err != io.EOF {
+		return fmt.Errorf("파일 수신 오류: %w", err)
+	}
+	return nil
+}
+
+// validateTreePath는 서버가 돌려준 트리 항목의 상대 경로를 destDir 아래에
+// 쓰기 전에 검증한다. 트리 다운로드는 하위 디렉토리를 위해 "/"를 허용해야
+// 하므로 validateFilename과는 다른 규칙을 쓰지만, ".."을 통한 경로 이탈은
+// 똑같이 막는다.
+func validateTreePath(relPath string) error {
+	if relPath == "" || !utf8.ValidString(relPath) {
+		return errors.New("올바르지 않은 경로입니다")
+	}
+	if path.IsAbs(relPath) {
+		return errors.New("절대 경로는 허용되지 않습니다")
+	}
+
+	clean := path.Clean(relPath)
+	if clean == ".." || clean == "." || strings.HasPrefix(clean, "../") {
+		return errors.New("경로 이탈을 시도하는 항목입니다")
+	}
+	return nil
+}
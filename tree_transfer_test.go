@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestValidateTreePath(t *testing.T) {
+	valid := []string{"a.txt", "sub/a.txt", "sub/sub2/a.txt"}
+	for _, p := range valid {
+		if err := validateTreePath(p); err != nil {
+			t.Errorf("validateTreePath(%q) = %v, want nil", p, err)
+		}
+	}
+
+	invalid := []string{"", ".", "..", "/abs/path", "../escape", "sub/../../escape"}
+	for _, p := range invalid {
+		if err := validateTreePath(p); err == nil {
+			t.Errorf("validateTreePath(%q) = nil, want error", p)
+		}
+	}
+}
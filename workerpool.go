@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// treeConcurrency는 디렉토리 트리 업로드/다운로드에서 동시에 열 수 있는
+// 최대 스트림 수이다.
+const treeConcurrency = 4
+
+// runPool은 [0,n) 범위의 인덱스를 최대 concurrency개의 워커로 나누어 work를
+// 실행한다. 하나라도 실패하면 아직 시작하지 않은 나머지 작업은 건너뛰고 첫
+// 에러를 반환한다.
+func runPool(concurrency, n int, work func(i int) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobs := make(chan int)
+	errOnce := sync.Once{}
+	var firstErr error
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := work(i); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return firstErr
+}